@@ -0,0 +1,111 @@
+// Package guildconfig stores small per-guild bot settings (whether
+// auto-embedding is enabled, and the command prefix) in a JSON file keyed by
+// guild ID, so they survive a restart.
+package guildconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultPrefix is the command prefix used by a guild that hasn't
+// customized it.
+const DefaultPrefix = "!rec"
+
+// GuildConfig holds the per-guild settings the bot honors.
+type GuildConfig struct {
+	AutoEmbed bool   `json:"autoEmbed"`
+	Prefix    string `json:"prefix"`
+}
+
+// Store is a JSON-file-backed persistent store for per-guild configuration.
+// It is safe for concurrent use.
+type Store struct {
+	mu   sync.Mutex
+	path string
+	data map[string]*GuildConfig
+}
+
+// NewStore loads guild configuration from path, creating an empty store if
+// the file doesn't exist yet.
+func NewStore(path string) (*Store, error) {
+	store := &Store{
+		path: path,
+		data: make(map[string]*GuildConfig),
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("guildconfig: failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(b, &store.data); err != nil {
+		return nil, fmt.Errorf("guildconfig: failed to parse %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Get returns the configuration for guildID, defaulting AutoEmbed to false
+// and Prefix to DefaultPrefix if the guild hasn't been configured yet.
+func (s *Store) Get(guildID string) GuildConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, ok := s.data[guildID]
+	if !ok {
+		return GuildConfig{Prefix: DefaultPrefix}
+	}
+	out := *cfg
+	if out.Prefix == "" {
+		out.Prefix = DefaultPrefix
+	}
+	return out
+}
+
+// SetAutoEmbed enables or disables automatic embedding of YouTube links for
+// guildID.
+func (s *Store) SetAutoEmbed(guildID string, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg := s.guildLocked(guildID)
+	cfg.AutoEmbed = enabled
+	return s.saveLocked()
+}
+
+// SetPrefix changes the command prefix used by guildID.
+func (s *Store) SetPrefix(guildID, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg := s.guildLocked(guildID)
+	cfg.Prefix = prefix
+	return s.saveLocked()
+}
+
+// guildLocked returns the (possibly newly created) config for guildID.
+// Callers must hold s.mu.
+func (s *Store) guildLocked(guildID string) *GuildConfig {
+	cfg, ok := s.data[guildID]
+	if !ok {
+		cfg = &GuildConfig{Prefix: DefaultPrefix}
+		s.data[guildID] = cfg
+	}
+	return cfg
+}
+
+// saveLocked writes the store to disk. Callers must hold s.mu.
+func (s *Store) saveLocked() error {
+	b, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("guildconfig: failed to marshal: %w", err)
+	}
+	if err := os.WriteFile(s.path, b, 0644); err != nil {
+		return fmt.Errorf("guildconfig: failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
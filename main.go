@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -14,10 +16,10 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/senseyeio/duration"
 	"github.com/sirupsen/logrus"
-)
 
-const (
-	command = "!rec"
+	"github.com/FlowingSPDG/discord-youtube-richembedded/guildconfig"
+	"github.com/FlowingSPDG/discord-youtube-richembedded/votestore"
+	"github.com/FlowingSPDG/discord-youtube-richembedded/ytapi"
 )
 
 var (
@@ -27,22 +29,68 @@ var (
 	// YouTube API Token
 	ytToken *string
 
+	// configPath is where per-guild settings (auto-embed, prefix) persist.
+	configPath *string
+
+	// votesPath is where vote tallies and saved-video lists persist.
+	votesPath *string
+
+	// ytdlpPath is the yt-dlp binary used as a fallback when the YouTube
+	// Data API is unavailable or its quota is exhausted.
+	ytdlpPath *string
+
+	// fallbackOnly skips the YouTube Data API entirely and always uses
+	// yt-dlp, for operators who don't want to provision an API key.
+	fallbackOnly *bool
+
 	youtubeService *youtube.Service
+	ytClient       ytapi.Client
+	guildStore     *guildconfig.Store
+	voteStore      *votestore.Store
 	stopBot        = make(chan struct{})
 )
 
-func init() {
+// setup parses flags and builds the globals main() depends on. It is kept
+// out of init() because init() runs before the testing package gets a
+// chance to register its own flags, which would make "go test" fail with
+// "flag provided but not defined: -test.v" before any test runs.
+func setup() {
 	DiscordToken = flag.String("discord", "", "Discord APP token. e.g. NTQwXX...")
-	ytToken = flag.String("youtube", "", "YouTube APP token. e.g. AIza...")
+	ytToken = flag.String("youtube", "", "YouTube APP token. e.g. AIza... (not required with --fallback-only)")
+	configPath = flag.String("config", "guildconfig.json", "Path to the per-guild configuration file")
+	votesPath = flag.String("votes", "votes.json", "Path to the vote tally / saved video file")
+	ytdlpPath = flag.String("ytdlp-path", "yt-dlp", "Path to the yt-dlp binary, used as a fallback when the YouTube Data API is unavailable")
+	fallbackOnly = flag.Bool("fallback-only", false, "Never call the YouTube Data API; always fetch metadata via yt-dlp")
 	flag.Parse()
 
-	if *DiscordToken == "" || *ytToken == "" {
+	if *DiscordToken == "" {
+		logrus.Panicf("Insufficient args...")
+	}
+	if *ytToken == "" && !*fallbackOnly {
 		logrus.Panicf("Insufficient args...")
 	}
 
 	*DiscordToken = "Bot " + *DiscordToken
 
-	youtubeService = initYoutubeService()
+	ytdlp := ytapi.NewYtdlpClient(*ytdlpPath)
+	if *fallbackOnly {
+		ytClient = ytdlp
+	} else {
+		youtubeService = initYoutubeService()
+		ytClient = ytapi.NewFallbackClient(ytapi.NewClient(youtubeService), ytdlp)
+	}
+
+	store, err := guildconfig.NewStore(*configPath)
+	if err != nil {
+		logrus.Fatalf("Failed to load guild config: %v", err)
+	}
+	guildStore = store
+
+	votes, err := votestore.NewStore(*votesPath)
+	if err != nil {
+		logrus.Fatalf("Failed to load vote store: %v", err)
+	}
+	voteStore = votes
 
 	customFormatter := new(logrus.TextFormatter)
 	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
@@ -71,23 +119,107 @@ func newYoutubeService(client *http.Client) *youtube.Service {
 	return service
 }
 
+// bareVideoIDPattern matches a YouTube video ID typed on its own, without a
+// surrounding URL.
+var bareVideoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// youtubeHosts are the hostnames (after stripping a leading "www.") that
+// getVideoID/getPlaylistID/getChannelID recognize as YouTube.
+var youtubeHosts = map[string]bool{
+	"youtube.com":       true,
+	"m.youtube.com":     true,
+	"music.youtube.com": true,
+}
+
+// normalizeYoutubeURL adds a scheme to s if it is missing one, so that
+// inputs like "www.youtube.com/watch?v=..." parse the same as a full URL.
+func normalizeYoutubeURL(s string) string {
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		return s
+	}
+	return "https://" + s
+}
+
+// getVideoID extracts a video ID from any common YouTube URL form
+// (youtube.com/watch, youtu.be, shorts, embed, live, music/mobile subdomains)
+// or a bare 11-character video ID. It is meant for the explicit "!rec <arg>"
+// command path, where a user typing just an ID is unambiguous; passive
+// scanning of ordinary chat should use getVideoIDFromURL instead, since
+// plenty of English words are also 11 characters of [A-Za-z0-9_-].
 func getVideoID(s string) (string, error) {
-	// TODO: parse non-URL youtube ID
+	if bareVideoIDPattern.MatchString(s) {
+		return s, nil
+	}
+	return getVideoIDFromURL(s)
+}
+
+// getVideoIDFromURL extracts a video ID from any common YouTube URL form,
+// without falling back to treating a bare string as a video ID.
+func getVideoIDFromURL(s string) (string, error) {
+	u, err := url.Parse(normalizeYoutubeURL(s))
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse URL : %s", err.Error())
+	}
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+
+	if host == "youtu.be" {
+		if id := strings.Trim(u.Path, "/"); id != "" {
+			return id, nil
+		}
+		return "", fmt.Errorf("URL Schema not valid")
+	}
+
+	if youtubeHosts[host] {
+		if videoID := u.Query().Get("v"); videoID != "" {
+			return videoID, nil
+		}
+		for _, prefix := range []string{"/shorts/", "/embed/", "/live/"} {
+			if strings.HasPrefix(u.Path, prefix) {
+				return strings.TrimPrefix(u.Path, prefix), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("URL Schema not valid")
+}
 
-	// Check URL schema...
-	u, err := url.Parse(s)
+// getPlaylistID extracts a playlist ID from a YouTube URL's "list" query
+// parameter.
+func getPlaylistID(s string) (string, error) {
+	u, err := url.Parse(normalizeYoutubeURL(s))
 	if err != nil {
 		return "", fmt.Errorf("Failed to parse URL : %s", err.Error())
 	}
-	q := u.Query()
-	videoID := q.Get("v")
-	if videoID != "" {
-		// return if ?v=... query found...
-		return videoID, nil
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+	if !youtubeHosts[host] {
+		return "", fmt.Errorf("URL Schema not valid")
 	}
-	if strings.HasPrefix(s, "https://youtu.be/") {
-		// return if s begin with https://youtu.be ...
-		return strings.TrimPrefix(s, "https://youtu.be/"), nil
+	if playlistID := u.Query().Get("list"); playlistID != "" {
+		return playlistID, nil
+	}
+	return "", fmt.Errorf("URL Schema not valid")
+}
+
+// getChannelID extracts a channel identifier from a YouTube channel URL. It
+// handles /channel/<id>, /@handle, and /c/<name> forms. Handles and custom
+// names are returned as-is (including the leading "@"); resolving them to a
+// numeric channel ID requires a Channels.List call with forHandle/forUsername.
+func getChannelID(s string) (string, error) {
+	u, err := url.Parse(normalizeYoutubeURL(s))
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse URL : %s", err.Error())
+	}
+	host := strings.TrimPrefix(u.Hostname(), "www.")
+	if !youtubeHosts[host] {
+		return "", fmt.Errorf("URL Schema not valid")
+	}
+	path := strings.Trim(u.Path, "/")
+	switch {
+	case strings.HasPrefix(path, "channel/"):
+		return strings.TrimPrefix(path, "channel/"), nil
+	case strings.HasPrefix(path, "@"):
+		return path, nil
+	case strings.HasPrefix(path, "c/"):
+		return strings.TrimPrefix(path, "c/"), nil
 	}
 	return "", fmt.Errorf("URL Schema not valid")
 }
@@ -97,51 +229,274 @@ func messageHandler(s *discordgo.Session, m *discordgo.MessageCreate) {
 		// Ignore bots
 		return
 	}
-	commands := strings.Split(m.Content, " ")
-	if len(commands) != 2 {
+
+	cfg := guildStore.Get(m.GuildID)
+	fields := strings.Split(m.Content, " ")
+	if fields[0] == cfg.Prefix {
+		handleCommand(s, m, cfg, fields)
 		return
 	}
-	if commands[0] != command {
+
+	if cfg.AutoEmbed {
+		autoEmbed(s, m)
+	}
+}
+
+// handleCommand dispatches an explicit "!rec ..." message: a recommend
+// request (the original behavior), one of the admin-only configuration
+// subcommands, or "saved" to list the author's saved videos.
+func handleCommand(s *discordgo.Session, m *discordgo.MessageCreate, cfg guildconfig.GuildConfig, fields []string) {
+	if len(fields) < 2 {
 		return
 	}
-	videoID, err := getVideoID(commands[1])
-	if err != nil {
-		sendError(s, m, err)
+
+	switch fields[1] {
+	case "enable-auto":
+		setAutoEmbed(s, m, true)
+	case "disable-auto":
+		setAutoEmbed(s, m, false)
+	case "prefix":
+		setPrefix(s, m, fields)
+	case "saved":
+		sendSavedVideos(s, m.ChannelID, m.Author.ID)
+	default:
+		if len(fields) != 2 {
+			return
+		}
+		if err := dispatchRecommend(s, m.GuildID, m.ChannelID, m.Author.ID, fields[1]); err != nil {
+			sendError(s, m.ChannelID, m.Author.ID, err)
+		}
+	}
+}
+
+// dispatchRecommend resolves arg as either a video, playlist, or channel
+// link (in that order, since a watch URL can carry both "v" and "list") and
+// posts the matching rich embed to channelID.
+func dispatchRecommend(s *discordgo.Session, guildID, channelID, authorID, arg string) error {
+	if videoID, err := getVideoID(arg); err == nil {
+		return sendVideoRecommend(s, guildID, channelID, authorID, videoID)
+	}
+	if playlistID, err := getPlaylistID(arg); err == nil {
+		return sendPlaylistRecommend(s, channelID, authorID, playlistID)
+	}
+	if ytChannelID, err := getChannelID(arg); err == nil {
+		return sendChannelRecommend(s, channelID, authorID, ytChannelID)
+	}
+	return fmt.Errorf("URL Schema not valid")
+}
+
+// isGuildAdmin reports whether m's author is a server administrator. Only
+// administrators may change per-guild configuration. Message events carry
+// no member permissions, so they are computed from the guild's roles and
+// the channel's overwrites.
+func isGuildAdmin(s *discordgo.Session, m *discordgo.MessageCreate) bool {
+	perms, err := s.UserChannelPermissions(m.Author.ID, m.ChannelID)
+	return err == nil && perms&discordgo.PermissionAdministrator != 0
+}
+
+func setAutoEmbed(s *discordgo.Session, m *discordgo.MessageCreate, enabled bool) {
+	if !isGuildAdmin(s, m) {
+		sendError(s, m.ChannelID, m.Author.ID, fmt.Errorf("このコマンドはサーバー管理者のみ実行できます"))
 		return
 	}
+	if err := guildStore.SetAutoEmbed(m.GuildID, enabled); err != nil {
+		sendError(s, m.ChannelID, m.Author.ID, err)
+		return
+	}
+	s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("自動埋め込みを%sにしました", map[bool]string{true: "有効", false: "無効"}[enabled]))
+}
 
-	call := youtubeService.Videos.List([]string{"id", "snippet", "contentDetails"}).Id(videoID).MaxResults(1)
-	resp, err := call.Do()
-	if err != nil {
-		sendError(s, m, err)
+func setPrefix(s *discordgo.Session, m *discordgo.MessageCreate, fields []string) {
+	if !isGuildAdmin(s, m) {
+		sendError(s, m.ChannelID, m.Author.ID, fmt.Errorf("このコマンドはサーバー管理者のみ実行できます"))
 		return
 	}
-	if len(resp.Items) != 1 {
-		sendError(s, m, fmt.Errorf("Item not found"))
+	if len(fields) != 3 {
+		sendError(s, m.ChannelID, m.Author.ID, fmt.Errorf("使い方: !rec prefix <新しいプレフィックス>"))
 		return
 	}
-	item := resp.Items[0]
+	if err := guildStore.SetPrefix(m.GuildID, fields[2]); err != nil {
+		sendError(s, m.ChannelID, m.Author.ID, err)
+		return
+	}
+	s.ChannelMessageSend(m.ChannelID, fmt.Sprintf("コマンドプレフィックスを %s にしました", fields[2]))
+}
 
-	duration, _ := duration.ParseISO8601(item.ContentDetails.Duration)
-	publishedAt, _ := time.Parse(time.RFC3339, item.Snippet.PublishedAt)
+// autoEmbed scans m for a YouTube link and, if one is found, posts a rich
+// embed for it and suppresses Discord's own link preview on the original
+// message.
+func autoEmbed(s *discordgo.Session, m *discordgo.MessageCreate) {
+	for _, token := range strings.Fields(m.Content) {
+		videoID, err := getVideoIDFromURL(token)
+		if err != nil {
+			continue
+		}
+		if err := sendVideoRecommend(s, m.GuildID, m.ChannelID, m.Author.ID, videoID); err != nil {
+			logrus.Errorf("ERROR : %v", err)
+			continue
+		}
+		suppressNativeEmbed(s, m)
+		return
+	}
+}
+
+// suppressNativeEmbed hides Discord's automatic link preview on m, since the
+// bot is about to post its own richer embed for the same link.
+func suppressNativeEmbed(s *discordgo.Session, m *discordgo.MessageCreate) {
+	edit := discordgo.NewMessageEdit(m.ChannelID, m.ID)
+	edit.Flags = discordgo.MessageFlagsSuppressEmbeds
+	if _, err := s.ChannelMessageEditComplex(edit); err != nil {
+		logrus.Errorf("ERROR : failed to suppress native embed : %v", err)
+	}
+}
+
+// sendVideoRecommend looks up videoID, builds a recommend, and posts it to
+// channelID.
+func sendVideoRecommend(s *discordgo.Session, guildID, channelID, authorID, videoID string) error {
+	video, err := ytClient.GetVideo(context.Background(), videoID)
+	if err != nil {
+		return err
+	}
+
+	channel, err := ytClient.GetChannel(context.Background(), video.ChannelId)
+	if err != nil {
+		// The yt-dlp client has no channel lookup; post the embed without
+		// the channel's thumbnail and subscriber count instead of failing.
+		logrus.Warnf("WARN : channel lookup failed, posting without channel details : %v", err)
+		channel = &ytapi.Channel{}
+	}
+
+	videoDuration, _ := duration.ParseISO8601(video.Duration)
+	publishedAt, _ := time.Parse(time.RFC3339, video.PublishedAt)
 	rec := recommend{
-		title:       item.Snippet.Title,
-		URL:         fmt.Sprintf("https://www.youtube.com/watch?v=%s", item.Id),
-		imageURL:    item.Snippet.Thumbnails.High.Url,
-		channelName: item.Snippet.ChannelTitle,
-		// channelThumbnailURL: item.Snippet.ChannelId, // TODO: solve channel thumbnail
-		channelURL:  fmt.Sprintf("https://www.youtube.com/channel/%s", item.Snippet.ChannelId),
-		description: item.Snippet.Description,
-		duration:    duration,
-		publishedAt: publishedAt,
+		videoID:             video.Id,
+		title:               video.Title,
+		URL:                 fmt.Sprintf("https://www.youtube.com/watch?v=%s", video.Id),
+		imageURL:            video.ThumbnailURL,
+		channelName:         video.ChannelTitle,
+		channelThumbnailURL: channel.ThumbnailURL,
+		channelURL:          fmt.Sprintf("https://www.youtube.com/channel/%s", video.ChannelId),
+		description:         video.Description,
+		duration:            videoDuration,
+		publishedAt:         publishedAt,
+		category:            video.Category,
+		viewCount:           video.ViewCount,
+		likeCount:           video.LikeCount,
+		subscriberCount:     channel.SubscriberCount,
+	}
+	return sendRecommend(s, guildID, channelID, authorID, rec)
+}
+
+// sendPlaylistRecommend looks up playlistID and posts an embed listing its
+// first few videos to channelID.
+func sendPlaylistRecommend(s *discordgo.Session, channelID, authorID, playlistID string) error {
+	playlist, err := ytClient.GetPlaylist(context.Background(), playlistID)
+	if err != nil {
+		return err
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(playlist.Items)+1)
+	for i, item := range playlist.Items {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%d. %s", i+1, item.Title),
+			Value:  fmt.Sprintf("https://www.youtube.com/watch?v=%s", item.VideoId),
+			Inline: false,
+		})
+	}
+	fields = append(fields, &discordgo.MessageEmbedField{
+		Name:   "RECOMMENDED BY",
+		Value:  fmt.Sprintf("<@%s>", authorID),
+		Inline: false,
+	})
+
+	embed := &discordgo.MessageEmbed{
+		URL:         fmt.Sprintf("https://www.youtube.com/playlist?list=%s", playlist.Id),
+		Title:       playlist.Title,
+		Type:        discordgo.EmbedTypeRich,
+		Description: playlist.Description,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Color:       0x00ff00,
+		Thumbnail: &discordgo.MessageEmbedThumbnail{
+			URL: playlist.ThumbnailURL,
+		},
+		Fields: fields,
+	}
+	_, err = s.ChannelMessageSendEmbed(channelID, embed)
+	return err
+}
+
+// sendChannelRecommend looks up ytChannelID and posts an embed with the
+// channel's stats and banner to channelID.
+func sendChannelRecommend(s *discordgo.Session, channelID, authorID, ytChannelID string) error {
+	channel, err := ytClient.GetChannel(context.Background(), ytChannelID)
+	if err != nil {
+		return err
+	}
+
+	embed := &discordgo.MessageEmbed{
+		URL:         fmt.Sprintf("https://www.youtube.com/channel/%s", channel.Id),
+		Title:       channel.Title,
+		Type:        discordgo.EmbedTypeRich,
+		Description: channel.Description,
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Color:       0x00ff00,
+		Image: &discordgo.MessageEmbedImage{
+			URL: channel.BannerURL,
+		},
+		Thumbnail: &discordgo.MessageEmbedThumbnail{
+			URL: channel.ThumbnailURL,
+		},
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "チャンネル登録者数",
+				Value:  fmt.Sprintf("%d", channel.SubscriberCount),
+				Inline: true,
+			},
+			{
+				Name:   "総再生回数",
+				Value:  fmt.Sprintf("%d", channel.ViewCount),
+				Inline: true,
+			},
+			{
+				Name:   "動画数",
+				Value:  fmt.Sprintf("%d", channel.VideoCount),
+				Inline: true,
+			},
+			{
+				Name:   "RECOMMENDED BY",
+				Value:  fmt.Sprintf("<@%s>", authorID),
+				Inline: false,
+			},
+		},
 	}
-	// logrus.Debugf("rec :", rec)
-	if err := sendRecommend(s, m, rec); err != nil {
-		logrus.Errorf("ERROR :", err)
+	_, err = s.ChannelMessageSendEmbed(channelID, embed)
+	return err
+}
+
+// sendSavedVideos posts authorID's saved video list to channelID.
+func sendSavedVideos(s *discordgo.Session, channelID, authorID string) {
+	saved := voteStore.SavedVideos(authorID)
+	if len(saved) == 0 {
+		s.ChannelMessageSend(channelID, "保存された動画はありません")
+		return
 	}
+
+	lines := make([]string, 0, len(saved))
+	for _, videoID := range saved {
+		lines = append(lines, fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID))
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       "保存された動画",
+		Type:        discordgo.EmbedTypeRich,
+		Description: strings.Join(lines, "\n"),
+		Timestamp:   time.Now().Format(time.RFC3339),
+		Color:       0x00ff00,
+	}
+	s.ChannelMessageSendEmbed(channelID, embed)
 }
 
 type recommend struct {
+	videoID             string
 	title               string
 	URL                 string
 	imageURL            string
@@ -151,9 +506,13 @@ type recommend struct {
 	description         string
 	duration            duration.Duration
 	publishedAt         time.Time
+	category            string
+	viewCount           uint64
+	likeCount           uint64
+	subscriberCount     uint64
 }
 
-func sendError(s *discordgo.Session, m *discordgo.MessageCreate, e error) error {
+func sendError(s *discordgo.Session, channelID, authorID string, e error) error {
 	embed := &discordgo.MessageEmbed{
 		Timestamp:   time.Now().Format(time.RFC3339), // Discord wants ISO8601; RFC3339 is an extension of ISO8601 and should be completely compatible.
 		Title:       "ERROR",
@@ -161,18 +520,18 @@ func sendError(s *discordgo.Session, m *discordgo.MessageCreate, e error) error
 		Color:       0xff0000, // RED?
 		Fields: []*discordgo.MessageEmbedField{{
 			Name:   "コマンド送信者",
-			Value:  fmt.Sprintf("<@%s>", m.Author.ID),
+			Value:  fmt.Sprintf("<@%s>", authorID),
 			Inline: false,
 		}},
 	}
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	_, err := s.ChannelMessageSendEmbed(channelID, embed)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func sendRecommend(s *discordgo.Session, m *discordgo.MessageCreate, rec recommend) error {
+func sendRecommend(s *discordgo.Session, guildID, channelID, authorID string, rec recommend) error {
 	embed := &discordgo.MessageEmbed{
 		URL:         rec.URL,
 		Title:       rec.title,
@@ -185,7 +544,7 @@ func sendRecommend(s *discordgo.Session, m *discordgo.MessageCreate, rec recomme
 			URL: rec.imageURL,
 		},
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
-			// URL: rec.channelThumbnailURL,
+			URL: rec.channelThumbnailURL,
 		},
 		Author: &discordgo.MessageEmbedAuthor{
 			Name:    rec.channelName,
@@ -213,35 +572,198 @@ func sendRecommend(s *discordgo.Session, m *discordgo.MessageCreate, rec recomme
 				Value:  fmt.Sprintf("%d時間%d分%d秒", rec.duration.TH,rec.duration.TM, rec.duration.TS),
 				Inline: true,
 			},
-			/*{
-				Name:   "Author Thumbnail URL",
-				Value:  rec.channelThumbnailURL,
+			{
+				Name:   "カテゴリ",
+				Value:  rec.category,
 				Inline: true,
-			},*/
+			},
+			{
+				Name:   "再生回数",
+				Value:  fmt.Sprintf("%d", rec.viewCount),
+				Inline: true,
+			},
+			{
+				Name:   "高評価数",
+				Value:  fmt.Sprintf("%d", rec.likeCount),
+				Inline: true,
+			},
+			{
+				Name:   "チャンネル登録者数",
+				Value:  fmt.Sprintf("%d", rec.subscriberCount),
+				Inline: true,
+			},
+			{
+				Name:   "評価",
+				Value:  formatVoteTally(voteStore.Tally(guildID, rec.videoID)),
+				Inline: true,
+			},
 			{
 				Name:   "RECOMMENDED BY",
-				Value:  fmt.Sprintf("<@%s>", m.Author.ID),
+				Value:  fmt.Sprintf("<@%s>", authorID),
 				Inline: false,
 			},
 		},
 	}
 	// logrus.Debugf("emb :", embed)
-	_, err := s.ChannelMessageSendEmbed(m.ChannelID, embed)
+	_, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+		Embed:      embed,
+		Components: buildRecommendComponents(rec),
+	})
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// formatVoteTally renders a vote tally as the "👍 N / 👎 M" text shown in the
+// embed and updated after every vote button press.
+func formatVoteTally(v votestore.Votes) string {
+	return fmt.Sprintf("👍 %d / 👎 %d", v.Up, v.Down)
+}
+
+// recommendCustomID component custom IDs are "rec:<action>:<videoID>". This
+// keeps the handler self-contained: it doesn't need to look anything up to
+// know what a button press should do.
+const recommendCustomIDPrefix = "rec:"
+
+func recommendCustomID(action, videoID string) string {
+	return recommendCustomIDPrefix + action + ":" + videoID
+}
+
+// buildRecommendComponents attaches vote/save/copy-link buttons to rec.
+//
+// Deviation from the original request: it also asked for a select menu of
+// related videos, built from Search.List's relatedToVideoId parameter.
+// YouTube dropped that parameter in 2023 and no current client library
+// exposes it, so that menu was dropped from this change instead of shipped
+// broken; flag this back to whoever filed the request. A replacement (e.g.
+// videos from the same channel or category) can be added back once it's
+// worth spending 100 quota units on every single embed.
+func buildRecommendComponents(rec recommend) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "👍 Upvote",
+					Style:    discordgo.SuccessButton,
+					CustomID: recommendCustomID("upvote", rec.videoID),
+				},
+				discordgo.Button{
+					Label:    "👎 Downvote",
+					Style:    discordgo.DangerButton,
+					CustomID: recommendCustomID("downvote", rec.videoID),
+				},
+				discordgo.Button{
+					Label:    "🔖 Save",
+					Style:    discordgo.SecondaryButton,
+					CustomID: recommendCustomID("save", rec.videoID),
+				},
+				discordgo.Button{
+					Label: "🔗 Copy Link",
+					Style: discordgo.LinkButton,
+					URL:   rec.URL,
+				},
+			},
+		},
+	}
+}
+
+// interactionHandler dispatches button clicks from the recommend embed's
+// components.
+func interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+
+	data := i.MessageComponentData()
+	parts := strings.SplitN(data.CustomID, ":", 3)
+	if len(parts) != 3 || parts[0]+":" != recommendCustomIDPrefix {
+		return
+	}
+	action, videoID := parts[1], parts[2]
+
+	switch action {
+	case "upvote", "downvote":
+		handleVote(s, i, action, videoID)
+	case "save":
+		handleSave(s, i, videoID)
+	}
+}
+
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+func handleVote(s *discordgo.Session, i *discordgo.InteractionCreate, action, videoID string) {
+	var (
+		tally votestore.Votes
+		err   error
+	)
+	if action == "upvote" {
+		tally, err = voteStore.Upvote(i.GuildID, videoID)
+	} else {
+		tally, err = voteStore.Downvote(i.GuildID, videoID)
+	}
+	if err != nil {
+		respondEphemeral(s, i, "投票に失敗しました: "+err.Error())
+		return
+	}
+
+	embed := i.Message.Embeds[0]
+	for _, field := range embed.Fields {
+		if field.Name == "評価" {
+			field.Value = formatVoteTally(tally)
+		}
+	}
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Components: i.Message.Components,
+		},
+	})
+}
+
+func handleSave(s *discordgo.Session, i *discordgo.InteractionCreate, videoID string) {
+	userID := interactionUserID(i)
+	if err := voteStore.SaveVideo(userID, videoID); err != nil {
+		respondEphemeral(s, i, "保存に失敗しました: "+err.Error())
+		return
+	}
+	respondEphemeral(s, i, fmt.Sprintf("保存しました: https://www.youtube.com/watch?v=%s", videoID))
+}
+
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
 func main() {
+	setup()
+
 	discord, err := discordgo.New()
 	discord.Token = *DiscordToken
 	if err != nil {
 		logrus.Fatalf("Failed to initialize discord session : %v\n", err)
 	}
+	// メッセージ本文を受け取るために必要
+	discord.Identify.Intents = discordgo.IntentsGuildMessages | discordgo.IntentsMessageContent
 
 	// メッセージを受信した時のハンドラーを追加
 	discord.AddHandler(messageHandler)
+	// ボタン操作を受信した時のハンドラーを追加
+	discord.AddHandler(interactionHandler)
 
 	// BOT起動
 	openerr := discord.Open()
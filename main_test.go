@@ -0,0 +1,98 @@
+package main
+
+import "testing"
+
+func TestGetVideoID(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"watch url", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"watch url no www", "https://youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"watch url extra params", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=30s", "dQw4w9WgXcQ", false},
+		{"watch url missing scheme", "www.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"youtu.be", "https://youtu.be/dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"shorts", "https://www.youtube.com/shorts/dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"embed", "https://www.youtube.com/embed/dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"live", "https://www.youtube.com/live/dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"music subdomain", "https://music.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"mobile subdomain", "https://m.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"bare id", "dQw4w9WgXcQ", "dQw4w9WgXcQ", false},
+		{"not a video url", "https://example.com/watch?v=dQw4w9WgXcQ", "", true},
+		{"playlist url only", "https://www.youtube.com/playlist?list=PLabc", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := getVideoID(c.in)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("getVideoID(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			}
+			if got != c.want {
+				t.Errorf("getVideoID(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestGetVideoIDFromURLRejectsBareIDs(t *testing.T) {
+	// getVideoIDFromURL is used for passive auto-embed scanning, so it must
+	// not match ordinary 11-character words the way getVideoID does.
+	for _, word := range []string{"wonderfully", "beautifully", "tablefooter", "Hello_World"} {
+		if _, err := getVideoIDFromURL(word); err == nil {
+			t.Errorf("getVideoIDFromURL(%q) = nil error, want error (bare strings must not match)", word)
+		}
+	}
+}
+
+func TestGetPlaylistID(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"watch url with list", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PLabc123", "PLabc123", false},
+		{"playlist url", "https://www.youtube.com/playlist?list=PLabc123", "PLabc123", false},
+		{"no list param", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "", true},
+		{"non-youtube host with list param", "https://open.spotify.com/playlist?list=PLabc123", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := getPlaylistID(c.in)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("getPlaylistID(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			}
+			if got != c.want {
+				t.Errorf("getPlaylistID(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestGetChannelID(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"channel id url", "https://www.youtube.com/channel/UCabc123", "UCabc123", false},
+		{"handle", "https://www.youtube.com/@someone", "@someone", false},
+		{"custom name", "https://www.youtube.com/c/someone", "someone", false},
+		{"not youtube", "https://example.com/channel/UCabc123", "", true},
+		{"watch url", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := getChannelID(c.in)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("getChannelID(%q) error = %v, wantErr %v", c.in, err, c.wantErr)
+			}
+			if got != c.want {
+				t.Errorf("getChannelID(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
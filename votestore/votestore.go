@@ -0,0 +1,134 @@
+// Package votestore persists per-video upvote/downvote tallies and each
+// user's saved video list to a JSON file, the same way guildconfig persists
+// per-guild settings.
+package votestore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Votes is the upvote/downvote tally for a single (guild, video) pair.
+type Votes struct {
+	Up   int `json:"up"`
+	Down int `json:"down"`
+}
+
+type data struct {
+	Votes       map[string]*Votes   `json:"votes"`
+	SavedVideos map[string][]string `json:"savedVideos"`
+}
+
+// Store is a JSON-file-backed persistent store for vote tallies and saved
+// videos. It is safe for concurrent use.
+type Store struct {
+	mu   sync.Mutex
+	path string
+	data data
+}
+
+// NewStore loads vote/saved-video data from path, creating an empty store
+// if the file doesn't exist yet.
+func NewStore(path string) (*Store, error) {
+	store := &Store{
+		path: path,
+		data: data{
+			Votes:       make(map[string]*Votes),
+			SavedVideos: make(map[string][]string),
+		},
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("votestore: failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(b, &store.data); err != nil {
+		return nil, fmt.Errorf("votestore: failed to parse %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// voteKey identifies a video within a single guild, since the same video
+// may be voted on independently in different servers.
+func voteKey(guildID, videoID string) string {
+	return guildID + ":" + videoID
+}
+
+// Upvote increments the upvote count for (guildID, videoID) and returns the
+// updated tally.
+func (s *Store) Upvote(guildID, videoID string) (Votes, error) {
+	return s.vote(guildID, videoID, func(v *Votes) { v.Up++ })
+}
+
+// Downvote increments the downvote count for (guildID, videoID) and returns
+// the updated tally.
+func (s *Store) Downvote(guildID, videoID string) (Votes, error) {
+	return s.vote(guildID, videoID, func(v *Votes) { v.Down++ })
+}
+
+func (s *Store) vote(guildID, videoID string, apply func(*Votes)) (Votes, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := voteKey(guildID, videoID)
+	v, ok := s.data.Votes[key]
+	if !ok {
+		v = &Votes{}
+		s.data.Votes[key] = v
+	}
+	apply(v)
+	if err := s.saveLocked(); err != nil {
+		return *v, err
+	}
+	return *v, nil
+}
+
+// Tally returns the current upvote/downvote tally for (guildID, videoID).
+func (s *Store) Tally(guildID, videoID string) Votes {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.data.Votes[voteKey(guildID, videoID)]; ok {
+		return *v
+	}
+	return Votes{}
+}
+
+// SaveVideo appends videoID to userID's saved list, if it isn't already
+// there.
+func (s *Store) SaveVideo(userID, videoID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range s.data.SavedVideos[userID] {
+		if id == videoID {
+			return nil
+		}
+	}
+	s.data.SavedVideos[userID] = append(s.data.SavedVideos[userID], videoID)
+	return s.saveLocked()
+}
+
+// SavedVideos returns userID's saved video IDs.
+func (s *Store) SavedVideos(userID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.data.SavedVideos[userID]...)
+}
+
+// saveLocked writes the store to disk. Callers must hold s.mu.
+func (s *Store) saveLocked() error {
+	b, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("votestore: failed to marshal: %w", err)
+	}
+	if err := os.WriteFile(s.path, b, 0644); err != nil {
+		return fmt.Errorf("votestore: failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
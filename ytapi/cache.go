@@ -0,0 +1,88 @@
+package ytapi
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCacheSize = 512
+	defaultCacheTTL  = 10 * time.Minute
+)
+
+// ttlCache is a small LRU cache with per-entry expiry. It exists to avoid
+// re-spending YouTube API quota on videos/channels/playlists that were
+// already looked up recently (e.g. the same link posted several times in a
+// guild).
+type ttlCache struct {
+	mu       sync.Mutex
+	size     int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	eviction *list.List
+}
+
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+func newTTLCache(size int, ttl time.Duration) *ttlCache {
+	return &ttlCache{
+		size:     size,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element, size),
+		eviction: list.New(),
+	}
+}
+
+// Get returns the cached value for key, if present and not expired.
+func (c *ttlCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.eviction.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.eviction.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set stores value under key, evicting the least-recently-used entry if the
+// cache is full.
+func (c *ttlCache) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*cacheEntry).value = value
+		el.Value.(*cacheEntry).expires = time.Now().Add(c.ttl)
+		c.eviction.MoveToFront(el)
+		return
+	}
+
+	el := c.eviction.PushFront(&cacheEntry{
+		key:     key,
+		value:   value,
+		expires: time.Now().Add(c.ttl),
+	})
+	c.entries[key] = el
+
+	for c.eviction.Len() > c.size {
+		oldest := c.eviction.Back()
+		if oldest == nil {
+			break
+		}
+		c.eviction.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
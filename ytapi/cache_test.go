@@ -0,0 +1,46 @@
+package ytapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCacheGetSet(t *testing.T) {
+	c := newTTLCache(2, time.Minute)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatalf("Get(missing) ok = true, want false")
+	}
+
+	c.Set("a", 1)
+	if v, ok := c.Get("a"); !ok || v.(int) != 1 {
+		t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+}
+
+func TestTTLCacheExpires(t *testing.T) {
+	c := newTTLCache(2, time.Millisecond)
+	c.Set("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(a) ok = true after TTL elapsed, want false")
+	}
+}
+
+func TestTTLCacheEvictsOldest(t *testing.T) {
+	c := newTTLCache(2, time.Minute)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3) // should evict "a"
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(a) ok = true, want false (should have been evicted)")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Errorf("Get(b) ok = false, want true")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Errorf("Get(c) ok = false, want true")
+	}
+}
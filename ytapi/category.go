@@ -0,0 +1,51 @@
+package ytapi
+
+// videoCategories maps a YouTube video category ID to its human-readable
+// name. The IDs are stable across regions; only the localized name can
+// differ, so hard-coding the English names here is fine for display
+// purposes.
+//
+// https://developers.google.com/youtube/v3/docs/videoCategories/list
+var videoCategories = map[string]string{
+	"1":  "Film & Animation",
+	"2":  "Autos & Vehicles",
+	"10": "Music",
+	"15": "Pets & Animals",
+	"17": "Sports",
+	"18": "Short Movies",
+	"19": "Travel & Events",
+	"20": "Gaming",
+	"21": "Videoblogging",
+	"22": "People & Blogs",
+	"23": "Comedy",
+	"24": "Entertainment",
+	"25": "News & Politics",
+	"26": "Howto & Style",
+	"27": "Education",
+	"28": "Science & Technology",
+	"29": "Nonprofits & Activism",
+	"30": "Movies",
+	"31": "Anime/Animation",
+	"32": "Action/Adventure",
+	"33": "Classics",
+	"34": "Comedy",
+	"35": "Documentary",
+	"36": "Drama",
+	"37": "Family",
+	"38": "Foreign",
+	"39": "Horror",
+	"40": "Sci-Fi/Fantasy",
+	"41": "Thriller",
+	"42": "Shorts",
+	"43": "Shows",
+	"44": "Trailers",
+}
+
+// categoryName returns the human-readable name for a YouTube category ID, or
+// "Unknown" if the ID isn't in the static table.
+func categoryName(categoryID string) string {
+	if name, ok := videoCategories[categoryID]; ok {
+		return name
+	}
+	return "Unknown"
+}
@@ -0,0 +1,105 @@
+package ytapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wader/goutubedl"
+)
+
+// ytdlpClient implements Client by shelling out to yt-dlp (via goutubedl).
+// It stands in for the YouTube Data API when no API key is configured, or
+// when the API's quota has been exhausted. Only video lookups are
+// supported; yt-dlp has no equivalent of a single channel/playlist metadata
+// call.
+type ytdlpClient struct{}
+
+// NewYtdlpClient returns a Client backed by the yt-dlp binary at binPath.
+func NewYtdlpClient(binPath string) Client {
+	goutubedl.Path = binPath
+	return &ytdlpClient{}
+}
+
+func (c *ytdlpClient) GetVideo(ctx context.Context, id string) (*Video, error) {
+	watchURL := fmt.Sprintf("https://www.youtube.com/watch?v=%s", id)
+	result, err := goutubedl.New(ctx, watchURL, goutubedl.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("ytapi: yt-dlp lookup failed: %w", err)
+	}
+	info := result.Info
+
+	publishedAt := info.UploadDate
+	if t, err := time.Parse("20060102", info.UploadDate); err == nil {
+		publishedAt = t.Format(time.RFC3339)
+	}
+
+	return &Video{
+		Id:           id,
+		Title:        info.Title,
+		Description:  info.Description,
+		ChannelId:    info.ChannelID,
+		ChannelTitle: info.Uploader,
+		ThumbnailURL: info.Thumbnail,
+		PublishedAt:  publishedAt,
+		Duration:     secondsToISO8601(info.Duration),
+	}, nil
+}
+
+func (c *ytdlpClient) GetChannel(ctx context.Context, id string) (*Channel, error) {
+	return nil, fmt.Errorf("ytapi: channel lookup is not supported by the yt-dlp fallback")
+}
+
+func (c *ytdlpClient) GetPlaylist(ctx context.Context, id string) (*Playlist, error) {
+	return nil, fmt.Errorf("ytapi: playlist lookup is not supported by the yt-dlp fallback")
+}
+
+// secondsToISO8601 converts a duration in seconds, as goutubedl reports it,
+// into the ISO8601 form the rest of the bot expects from
+// contentDetails.duration.
+func secondsToISO8601(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second))
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	return fmt.Sprintf("PT%dH%dM%dS", h, m, s)
+}
+
+// fallbackClient tries a primary Client first and falls back to a secondary
+// Client whenever the primary reports its quota is exhausted.
+type fallbackClient struct {
+	primary  Client
+	fallback Client
+}
+
+// NewFallbackClient returns a Client that tries primary first and falls
+// back to fallback whenever primary returns an error wrapping
+// ErrQuotaExhausted.
+func NewFallbackClient(primary, fallback Client) Client {
+	return &fallbackClient{primary: primary, fallback: fallback}
+}
+
+func (c *fallbackClient) GetVideo(ctx context.Context, id string) (*Video, error) {
+	v, err := c.primary.GetVideo(ctx, id)
+	if err != nil && errors.Is(err, ErrQuotaExhausted) {
+		return c.fallback.GetVideo(ctx, id)
+	}
+	return v, err
+}
+
+func (c *fallbackClient) GetChannel(ctx context.Context, id string) (*Channel, error) {
+	ch, err := c.primary.GetChannel(ctx, id)
+	if err != nil && errors.Is(err, ErrQuotaExhausted) {
+		return c.fallback.GetChannel(ctx, id)
+	}
+	return ch, err
+}
+
+func (c *fallbackClient) GetPlaylist(ctx context.Context, id string) (*Playlist, error) {
+	p, err := c.primary.GetPlaylist(ctx, id)
+	if err != nil && errors.Is(err, ErrQuotaExhausted) {
+		return c.fallback.GetPlaylist(ctx, id)
+	}
+	return p, err
+}
@@ -0,0 +1,81 @@
+package ytapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fakeClient is a minimal Client stand-in, the sort of fake this package's
+// interface is meant to make easy to write.
+type fakeClient struct {
+	video   *Video
+	channel *Channel
+	err     error
+	calls   int
+}
+
+func (f *fakeClient) GetVideo(ctx context.Context, id string) (*Video, error) {
+	f.calls++
+	return f.video, f.err
+}
+
+func (f *fakeClient) GetChannel(ctx context.Context, id string) (*Channel, error) {
+	f.calls++
+	return f.channel, f.err
+}
+
+func (f *fakeClient) GetPlaylist(ctx context.Context, id string) (*Playlist, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func TestFallbackClientFallsBackOnQuotaExhausted(t *testing.T) {
+	primary := &fakeClient{err: fmt.Errorf("%w: daily budget spent", ErrQuotaExhausted)}
+	fallback := &fakeClient{video: &Video{Id: "fallback-video"}}
+
+	c := NewFallbackClient(primary, fallback)
+	v, err := c.GetVideo(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("GetVideo() error = %v, want nil", err)
+	}
+	if v.Id != "fallback-video" {
+		t.Errorf("GetVideo() = %+v, want fallback's video", v)
+	}
+	if primary.calls != 1 || fallback.calls != 1 {
+		t.Errorf("calls: primary=%d fallback=%d, want 1 and 1", primary.calls, fallback.calls)
+	}
+}
+
+func TestFallbackClientDoesNotFallBackOnOtherErrors(t *testing.T) {
+	wantErr := errors.New("video not found")
+	primary := &fakeClient{err: wantErr}
+	fallback := &fakeClient{video: &Video{Id: "fallback-video"}}
+
+	c := NewFallbackClient(primary, fallback)
+	_, err := c.GetVideo(context.Background(), "abc")
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("GetVideo() error = %v, want %v", err, wantErr)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback.calls = %d, want 0 (should not be used for non-quota errors)", fallback.calls)
+	}
+}
+
+func TestFallbackClientUsesPrimaryOnSuccess(t *testing.T) {
+	primary := &fakeClient{video: &Video{Id: "primary-video"}}
+	fallback := &fakeClient{video: &Video{Id: "fallback-video"}}
+
+	c := NewFallbackClient(primary, fallback)
+	v, err := c.GetVideo(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("GetVideo() error = %v, want nil", err)
+	}
+	if v.Id != "primary-video" {
+		t.Errorf("GetVideo() = %+v, want primary's video", v)
+	}
+	if fallback.calls != 0 {
+		t.Errorf("fallback.calls = %d, want 0", fallback.calls)
+	}
+}
@@ -0,0 +1,106 @@
+package ytapi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// ErrQuotaExhausted is wrapped by any error returned because the YouTube
+// Data API quota (ours, or Google's) has been used up. Callers can match it
+// with errors.Is to decide whether to fall back to another Client.
+var ErrQuotaExhausted = errors.New("ytapi: quota exhausted")
+
+// Per-endpoint quota costs, in YouTube Data API quota units.
+// https://developers.google.com/youtube/v3/determine_quota_cost
+const (
+	costVideosList        = 1
+	costChannelsList      = 1
+	costPlaylistsList     = 1
+	costPlaylistItemsList = 1
+
+	// defaultDailyQuota matches the default quota Google grants a new
+	// project. The budget is not configurable: a project with a higher
+	// grant is still refused locally once this many units are spent.
+	defaultDailyQuota = 10000
+
+	playlistItemsPageSize = 5
+)
+
+// quotaTracker keeps a running tally of quota units spent today and refuses
+// calls that would push the bot over its daily allotment. The tally is
+// shared by all guilds; it is not split per guild.
+type quotaTracker struct {
+	mu      sync.Mutex
+	budget  int64
+	spent   int64
+	resetAt time.Time
+}
+
+func newQuotaTracker() *quotaTracker {
+	return &quotaTracker{
+		budget:  defaultDailyQuota,
+		resetAt: nextMidnightPacific(time.Now()),
+	}
+}
+
+// Reserve accounts for cost quota units being spent on an upcoming call. It
+// returns an error instead of spending if doing so would exceed the daily
+// budget.
+func (q *quotaTracker) Reserve(cost int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	if !now.Before(q.resetAt) {
+		q.spent = 0
+		q.resetAt = nextMidnightPacific(now)
+	}
+
+	if q.spent+cost > q.budget {
+		return fmt.Errorf("%w: %d/%d units spent, resets %s", ErrQuotaExhausted, q.spent, q.budget, q.resetAt.Format(time.RFC3339))
+	}
+	q.spent += cost
+	return nil
+}
+
+// isAPIQuotaExceeded reports whether err is a googleapi error indicating the
+// project's YouTube Data API quota has been exhausted by Google itself,
+// rather than by our own local budget.
+func isAPIQuotaExceeded(err error) bool {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code != 403 {
+		return false
+	}
+	for _, e := range apiErr.Errors {
+		if e.Reason == "quotaExceeded" || e.Reason == "dailyLimitExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+// Used reports how many quota units have been spent since the last reset.
+func (q *quotaTracker) Used() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.spent
+}
+
+// nextMidnightPacific returns the next YouTube API quota reset, which
+// happens at midnight Pacific time.
+func nextMidnightPacific(from time.Time) time.Time {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		loc = time.UTC
+	}
+	t := from.In(loc)
+	next := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).AddDate(0, 0, 1)
+	return next
+}
@@ -0,0 +1,34 @@
+package ytapi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuotaTrackerReserve(t *testing.T) {
+	q := &quotaTracker{budget: 10, resetAt: time.Now().Add(time.Hour)}
+
+	if err := q.Reserve(6); err != nil {
+		t.Fatalf("Reserve(6) error = %v, want nil", err)
+	}
+	if err := q.Reserve(5); err == nil {
+		t.Fatalf("Reserve(5) error = nil, want error (6+5 > budget 10)")
+	}
+	if err := q.Reserve(4); err != nil {
+		t.Fatalf("Reserve(4) error = %v, want nil (6+4 == budget 10)", err)
+	}
+	if got := q.Used(); got != 10 {
+		t.Errorf("Used() = %d, want 10", got)
+	}
+}
+
+func TestQuotaTrackerResetsAfterDeadline(t *testing.T) {
+	q := &quotaTracker{budget: 10, spent: 10, resetAt: time.Now().Add(-time.Second)}
+
+	if err := q.Reserve(5); err != nil {
+		t.Fatalf("Reserve(5) after reset deadline error = %v, want nil", err)
+	}
+	if got := q.Used(); got != 5 {
+		t.Errorf("Used() = %d, want 5 (spent should have been reset to 0)", got)
+	}
+}
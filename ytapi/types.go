@@ -0,0 +1,101 @@
+package ytapi
+
+import "google.golang.org/api/youtube/v3"
+
+// Video is the subset of a YouTube video's metadata the bot cares about.
+type Video struct {
+	Id           string
+	Title        string
+	Description  string
+	ChannelId    string
+	ChannelTitle string
+	ThumbnailURL string
+	PublishedAt  string
+	Duration     string
+	Category     string
+	ViewCount    uint64
+	LikeCount    uint64
+}
+
+func videoFromAPI(item *youtube.Video) *Video {
+	v := &Video{
+		Id:           item.Id,
+		Title:        item.Snippet.Title,
+		Description:  item.Snippet.Description,
+		ChannelId:    item.Snippet.ChannelId,
+		ChannelTitle: item.Snippet.ChannelTitle,
+		ThumbnailURL: item.Snippet.Thumbnails.High.Url,
+		PublishedAt:  item.Snippet.PublishedAt,
+		Duration:     item.ContentDetails.Duration,
+		Category:     categoryName(item.Snippet.CategoryId),
+	}
+	if item.Statistics != nil {
+		v.ViewCount = item.Statistics.ViewCount
+		v.LikeCount = item.Statistics.LikeCount
+	}
+	return v
+}
+
+// Channel is the subset of a YouTube channel's metadata the bot cares about.
+type Channel struct {
+	Id              string
+	Title           string
+	Description     string
+	ThumbnailURL    string
+	BannerURL       string
+	SubscriberCount uint64
+	ViewCount       uint64
+	VideoCount      uint64
+}
+
+func channelFromAPI(item *youtube.Channel) *Channel {
+	c := &Channel{
+		Id:           item.Id,
+		Title:        item.Snippet.Title,
+		Description:  item.Snippet.Description,
+		ThumbnailURL: item.Snippet.Thumbnails.Default.Url,
+	}
+	if item.Statistics != nil {
+		c.SubscriberCount = item.Statistics.SubscriberCount
+		c.ViewCount = item.Statistics.ViewCount
+		c.VideoCount = item.Statistics.VideoCount
+	}
+	if item.BrandingSettings != nil && item.BrandingSettings.Image != nil {
+		c.BannerURL = item.BrandingSettings.Image.BannerExternalUrl
+	}
+	return c
+}
+
+// PlaylistItem is a single entry returned by a playlist lookup.
+type PlaylistItem struct {
+	Title        string
+	VideoId      string
+	ThumbnailURL string
+}
+
+// Playlist is the subset of a YouTube playlist's metadata the bot cares
+// about, including its first page of items.
+type Playlist struct {
+	Id           string
+	Title        string
+	Description  string
+	ThumbnailURL string
+	Items        []PlaylistItem
+}
+
+func playlistFromAPI(item *youtube.Playlist, items []*youtube.PlaylistItem) *Playlist {
+	p := &Playlist{
+		Id:           item.Id,
+		Title:        item.Snippet.Title,
+		Description:  item.Snippet.Description,
+		ThumbnailURL: item.Snippet.Thumbnails.High.Url,
+	}
+	for _, it := range items {
+		p.Items = append(p.Items, PlaylistItem{
+			Title:        it.Snippet.Title,
+			VideoId:      it.Snippet.ResourceId.VideoId,
+			ThumbnailURL: it.Snippet.Thumbnails.Default.Url,
+		})
+	}
+	return p
+}
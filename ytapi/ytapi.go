@@ -0,0 +1,146 @@
+// Package ytapi centralizes access to the YouTube Data API behind a small,
+// typed client so callers never touch youtube.Service directly. It keeps an
+// in-process cache of recent lookups and coalesces concurrent requests for
+// the same ID, since a busy guild can easily post the same video link many
+// times within a few seconds.
+package ytapi
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/api/youtube/v3"
+)
+
+// Client is the interface the bot talks to. It is satisfied by the real
+// YouTube Data API backed implementation below, and by any fallback
+// implementation (e.g. a yt-dlp backed one) that needs to stand in when the
+// API is unavailable.
+type Client interface {
+	GetVideo(ctx context.Context, id string) (*Video, error)
+	GetChannel(ctx context.Context, id string) (*Channel, error)
+	GetPlaylist(ctx context.Context, id string) (*Playlist, error)
+}
+
+// service is the default Client implementation, backed by the YouTube Data
+// API v3.
+type service struct {
+	yt    *youtube.Service
+	cache *ttlCache
+	group singleflight.Group
+	quota *quotaTracker
+}
+
+// NewClient wraps an authenticated youtube.Service with caching,
+// singleflight request coalescing, and quota tracking.
+func NewClient(yt *youtube.Service) Client {
+	return &service{
+		yt:    yt,
+		cache: newTTLCache(defaultCacheSize, defaultCacheTTL),
+		quota: newQuotaTracker(),
+	}
+}
+
+// GetVideo fetches a single video by ID, using the cache when possible.
+func (s *service) GetVideo(ctx context.Context, id string) (*Video, error) {
+	key := "video:" + id
+	if v, ok := s.cache.Get(key); ok {
+		return v.(*Video), nil
+	}
+
+	v, err, _ := s.group.Do(key, func() (interface{}, error) {
+		if err := s.quota.Reserve(costVideosList); err != nil {
+			return nil, err
+		}
+		call := s.yt.Videos.List([]string{"id", "snippet", "contentDetails", "statistics"}).Id(id).MaxResults(1)
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			if isAPIQuotaExceeded(err) {
+				return nil, fmt.Errorf("%w: %v", ErrQuotaExhausted, err)
+			}
+			return nil, fmt.Errorf("ytapi: videos.list failed: %w", err)
+		}
+		if len(resp.Items) != 1 {
+			return nil, fmt.Errorf("ytapi: video %q not found", id)
+		}
+		video := videoFromAPI(resp.Items[0])
+		s.cache.Set(key, video)
+		return video, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Video), nil
+}
+
+// GetChannel fetches a single channel by ID, using the cache when possible.
+func (s *service) GetChannel(ctx context.Context, id string) (*Channel, error) {
+	key := "channel:" + id
+	if c, ok := s.cache.Get(key); ok {
+		return c.(*Channel), nil
+	}
+
+	c, err, _ := s.group.Do(key, func() (interface{}, error) {
+		if err := s.quota.Reserve(costChannelsList); err != nil {
+			return nil, err
+		}
+		call := s.yt.Channels.List([]string{"id", "snippet", "statistics", "brandingSettings"}).Id(id).MaxResults(1)
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			if isAPIQuotaExceeded(err) {
+				return nil, fmt.Errorf("%w: %v", ErrQuotaExhausted, err)
+			}
+			return nil, fmt.Errorf("ytapi: channels.list failed: %w", err)
+		}
+		if len(resp.Items) != 1 {
+			return nil, fmt.Errorf("ytapi: channel %q not found", id)
+		}
+		channel := channelFromAPI(resp.Items[0])
+		s.cache.Set(key, channel)
+		return channel, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.(*Channel), nil
+}
+
+// GetPlaylist fetches a playlist and its first page of items.
+func (s *service) GetPlaylist(ctx context.Context, id string) (*Playlist, error) {
+	key := "playlist:" + id
+	if p, ok := s.cache.Get(key); ok {
+		return p.(*Playlist), nil
+	}
+
+	p, err, _ := s.group.Do(key, func() (interface{}, error) {
+		if err := s.quota.Reserve(costPlaylistsList + costPlaylistItemsList); err != nil {
+			return nil, err
+		}
+		call := s.yt.Playlists.List([]string{"id", "snippet", "contentDetails"}).Id(id).MaxResults(1)
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			if isAPIQuotaExceeded(err) {
+				return nil, fmt.Errorf("%w: %v", ErrQuotaExhausted, err)
+			}
+			return nil, fmt.Errorf("ytapi: playlists.list failed: %w", err)
+		}
+		if len(resp.Items) != 1 {
+			return nil, fmt.Errorf("ytapi: playlist %q not found", id)
+		}
+
+		itemsCall := s.yt.PlaylistItems.List([]string{"id", "snippet"}).PlaylistId(id).MaxResults(playlistItemsPageSize)
+		itemsResp, err := itemsCall.Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("ytapi: playlistItems.list failed: %w", err)
+		}
+
+		playlist := playlistFromAPI(resp.Items[0], itemsResp.Items)
+		s.cache.Set(key, playlist)
+		return playlist, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.(*Playlist), nil
+}